@@ -0,0 +1,387 @@
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+var (
+	bigOne  = big.NewInt(1)
+	bigFour = big.NewInt(4)
+)
+
+const (
+	// divisorSearchLimit bounds the values for which aliquotSum (and everything
+	// built on it: perfect/deficient/abundant/amicable) runs its O(sqrt n) trial
+	// division. Beyond this the search would take longer than is reasonable for
+	// an HTTP request, so those properties are simply omitted for huge inputs.
+	//
+	// classify-numbers classifies attacker-fetchable numbers concurrently under a
+	// caller-supplied deadline, so this also doubles as a per-call CPU budget: kept
+	// low enough (sqrt ~= 10^4 trial divisions) that a single classify() call can't
+	// meaningfully overrun the deadline even if it's already in flight when the
+	// deadline fires.
+	divisorSearchLimit = 100_000_000 // sqrt ~= 10^4 trial divisions
+
+	// strictPalindromeSearchLimit bounds the values for which isStrictlyNonPalindromic
+	// checks every base from 2 to n-2; that scan is O(n), not O(sqrt n), so it needs a
+	// much tighter limit than divisorSearchLimit to stay within the same CPU budget.
+	strictPalindromeSearchLimit = 10_000
+)
+
+// fitsWithin reports whether the non-negative n is small enough to fit in an
+// int64 and not exceed limit, i.e. whether an O(n)-or-worse scan over n is safe
+// to run synchronously within an HTTP request.
+func fitsWithin(n *big.Int, limit int64) bool {
+	return n.IsInt64() && n.Int64() <= limit
+}
+
+// Classification holds the pure, network-independent mathematical properties of a
+// number. It is the shared core used by both the single-number endpoint and the
+// batch fan-out endpoint, so the two never drift apart on what "armstrong", "prime",
+// etc. mean.
+//
+// Number is backed by math/big.Int so arbitrarily large inputs classify without
+// overflow. Primality, perfectness, armstrong-ness, and the divisor-based tags are
+// all evaluated on the absolute value of the number; Sign reports the original
+// number's sign (-1, 0, or 1) separately.
+type Classification struct {
+	Number      *big.Int `json:"-"`            // The input number being classified; marshaled as a string below
+	Sign        int      `json:"sign"`         // -1, 0, or 1
+	Base        int      `json:"base"`         // Base digit_sum and armstrong-ness were evaluated in
+	IsPrime     bool     `json:"is_prime"`     // Whether |n| is prime
+	IsPerfect   bool     `json:"is_perfect"`   // Whether |n| is perfect (aliquot sum == |n|)
+	IsDeficient bool     `json:"is_deficient"` // Whether |n| is deficient (aliquot sum < |n|)
+	IsAbundant  bool     `json:"is_abundant"`  // Whether |n| is abundant (aliquot sum > |n|)
+	IsHappy     bool     `json:"is_happy"`     // Whether repeated digit-square summing reaches 1
+	Properties  []string `json:"properties"`   // List of properties (e.g., "armstrong", "odd", "even")
+	DigitSum    int64    `json:"digit_sum"`    // Sum of |n|'s digits in the requested base
+}
+
+// MarshalJSON encodes Number as a decimal string instead of math/big.Int's
+// default raw JSON number. Non-Go clients (notably JavaScript) parse JSON
+// numbers as float64, which silently loses precision past 2^53 — exactly the
+// arbitrary-precision guarantee Number exists to provide.
+func (c Classification) MarshalJSON() ([]byte, error) {
+	type alias Classification
+	return json.Marshal(struct {
+		Number string `json:"number"`
+		alias
+	}{
+		Number: c.Number.String(),
+		alias:  alias(c),
+	})
+}
+
+// classify computes the full set of mathematical properties for n, interpreting
+// digit-based properties (armstrong, digit_sum) in the given base. It performs no
+// I/O, so it is safe to call from concurrent fan-out workers without any context
+// or cancellation plumbing.
+func classify(n *big.Int, base int) Classification {
+	abs := new(big.Int).Abs(n)
+
+	var properties []string
+
+	if isArmstrong(abs, base) {
+		properties = append(properties, "armstrong")
+	}
+	if abs.Bit(0) == 0 {
+		properties = append(properties, "even")
+	} else {
+		properties = append(properties, "odd")
+	}
+
+	var isPerfect, isDeficient, isAbundant bool
+	if fitsWithin(abs, divisorSearchLimit) {
+		sum := aliquotSum(abs)
+		switch {
+		case abs.Sign() > 0 && sum.Cmp(abs) == 0:
+			isPerfect = true
+			properties = append(properties, "perfect")
+		case abs.Sign() > 0 && sum.Cmp(abs) > 0:
+			isAbundant = true
+			properties = append(properties, "abundant")
+		case abs.Sign() > 0:
+			isDeficient = true
+			properties = append(properties, "deficient")
+		}
+		if isAmicable(abs) {
+			properties = append(properties, "amicable")
+		}
+	}
+
+	happy := isHappy(abs)
+	if happy {
+		properties = append(properties, "happy")
+	} else {
+		properties = append(properties, "unhappy")
+	}
+
+	if isPalindrome(abs) {
+		properties = append(properties, "palindrome")
+	}
+	if isPronic(abs) {
+		properties = append(properties, "pronic")
+	}
+	if fitsWithin(abs, strictPalindromeSearchLimit) && isStrictlyNonPalindromic(abs) {
+		properties = append(properties, "strictly_non_palindromic")
+	}
+
+	return Classification{
+		Number:      n,
+		Sign:        n.Sign(),
+		Base:        base,
+		IsPrime:     isPrime(abs),
+		IsPerfect:   isPerfect,
+		IsDeficient: isDeficient,
+		IsAbundant:  isAbundant,
+		IsHappy:     happy,
+		Properties:  properties,
+		DigitSum:    digitSum(abs, base),
+	}
+}
+
+// isArmstrong checks if n (assumed non-negative) is an Armstrong number in the
+// given base. An Armstrong number is one that equals the sum of its own digits,
+// each raised to the power of the digit count, all evaluated in that base.
+//
+// Examples:
+// - 371 is an Armstrong number in base 10 because 3^3 + 7^3 + 1^3 = 371
+// - 153 is an Armstrong number in base 10 because 1^3 + 5^3 + 3^3 = 153
+//
+// Parameters:
+//   - n: The non-negative number to check
+//   - base: The base (2..36) to evaluate digits in
+//
+// Returns:
+//   - bool: true if n is an Armstrong number in base, false otherwise
+func isArmstrong(n *big.Int, base int) bool {
+	digits := digitsInBase(n, big.NewInt(int64(base)))
+	power := big.NewInt(int64(len(digits)))
+
+	sum := new(big.Int)
+	for _, digit := range digits {
+		sum.Add(sum, new(big.Int).Exp(digit, power, nil))
+	}
+
+	return sum.Cmp(n) == 0
+}
+
+// isPrime determines if n (assumed non-negative) is prime using the
+// probabilistic Miller-Rabin/Baillie-PSW test built into math/big, which stays
+// fast regardless of how many digits n has.
+//
+// Parameters:
+//   - n: The non-negative number to check for primality
+//
+// Returns:
+//   - bool: true if n is (probably) prime, false otherwise
+func isPrime(n *big.Int) bool {
+	if n.Cmp(bigOne) <= 0 {
+		return false
+	}
+	return n.ProbablyPrime(20)
+}
+
+// aliquotSum returns the sum of the proper positive divisors of n (assumed
+// non-negative), all divisors excluding n itself. It is the basis for
+// perfect/deficient/abundant classification and for detecting amicable pairs.
+//
+// Parameters:
+//   - n: The non-negative number whose proper divisors are to be summed
+//
+// Returns:
+//   - *big.Int: The sum of n's proper divisors
+func aliquotSum(n *big.Int) *big.Int {
+	if n.Cmp(bigOne) <= 0 {
+		return new(big.Int)
+	}
+
+	sum := big.NewInt(1)
+	sqrt := new(big.Int).Sqrt(n)
+	quotient, remainder := new(big.Int), new(big.Int)
+
+	for i := big.NewInt(2); i.Cmp(sqrt) <= 0; i.Add(i, bigOne) {
+		quotient.QuoRem(n, i, remainder)
+		if remainder.Sign() == 0 {
+			sum.Add(sum, i)
+			if quotient.Cmp(i) != 0 {
+				sum.Add(sum, quotient)
+			}
+		}
+	}
+	return sum
+}
+
+// isAmicable checks whether n (assumed non-negative) is part of an amicable
+// pair: n and some other number m, each equal to the sum of the other's proper
+// divisors.
+//
+// Examples:
+// - 220 and 284 are amicable because aliquotSum(220) = 284 and aliquotSum(284) = 220
+//
+// Parameters:
+//   - n: The non-negative number to check
+//
+// Returns:
+//   - bool: true if n is amicable, false otherwise
+func isAmicable(n *big.Int) bool {
+	if n.Sign() <= 0 {
+		return false
+	}
+	m := aliquotSum(n)
+	if m.Sign() <= 0 || m.Cmp(n) == 0 {
+		return false
+	}
+	return aliquotSum(m).Cmp(n) == 0
+}
+
+// isHappy checks if n (assumed non-negative) is happy: repeatedly replacing it
+// with the sum of the squares of its digits eventually reaches 1.
+//
+// All unhappy numbers eventually fall into the same cycle 4 -> 16 -> 37 -> 58 ->
+// 89 -> 145 -> 42 -> 20 -> 4, so hitting 4 is a reliable short-circuit for "never
+// reaches 1" without tracking every previously seen value.
+//
+// Parameters:
+//   - n: The non-negative number to check
+//
+// Returns:
+//   - bool: true if n is happy, false otherwise
+func isHappy(n *big.Int) bool {
+	if n.Sign() <= 0 {
+		return false
+	}
+	value := n
+	for value.Cmp(bigOne) != 0 && value.Cmp(bigFour) != 0 {
+		value = sumOfSquaredDigits(value)
+	}
+	return value.Cmp(bigOne) == 0
+}
+
+// sumOfSquaredDigits returns the sum of the squares of n's base-10 digits, the
+// step function used to test for happy numbers.
+func sumOfSquaredDigits(n *big.Int) *big.Int {
+	sum := new(big.Int)
+	ten := big.NewInt(10)
+	quotient, digit := new(big.Int), new(big.Int)
+	remaining := new(big.Int).Set(n)
+
+	for remaining.Sign() > 0 {
+		quotient.QuoRem(remaining, ten, digit)
+		sum.Add(sum, new(big.Int).Mul(digit, digit))
+		remaining, quotient = quotient, remaining
+	}
+	return sum
+}
+
+// isPalindrome checks if n (assumed non-negative) reads the same forwards and
+// backwards in base 10.
+//
+// Parameters:
+//   - n: The non-negative number to check
+//
+// Returns:
+//   - bool: true if n is a base-10 palindrome, false otherwise
+func isPalindrome(n *big.Int) bool {
+	return isPalindromeInBase(n, big.NewInt(10))
+}
+
+// isPronic checks if n (assumed non-negative) is a pronic (oblong) number,
+// i.e. n = k*(k+1) for some non-negative integer k.
+//
+// Examples:
+// - 12 is pronic because 3*4 = 12
+// - 20 is pronic because 4*5 = 20
+//
+// Parameters:
+//   - n: The non-negative number to check
+//
+// Returns:
+//   - bool: true if n is pronic, false otherwise
+func isPronic(n *big.Int) bool {
+	k := new(big.Int).Sqrt(n)
+	for _, delta := range []int64{-1, 0, 1} {
+		candidate := new(big.Int).Add(k, big.NewInt(delta))
+		if candidate.Sign() < 0 {
+			continue
+		}
+		next := new(big.Int).Add(candidate, bigOne)
+		if new(big.Int).Mul(candidate, next).Cmp(n) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isStrictlyNonPalindromic checks if n (assumed non-negative) is not a
+// palindrome in any base from 2 up to n-2 inclusive. Numbers for which that
+// range is empty (n < 4) are vacuously strictly non-palindromic.
+//
+// Parameters:
+//   - n: The non-negative number to check
+//
+// Returns:
+//   - bool: true if n is strictly non-palindromic, false otherwise
+func isStrictlyNonPalindromic(n *big.Int) bool {
+	upper := new(big.Int).Sub(n, big.NewInt(2))
+	for base := big.NewInt(2); base.Cmp(upper) <= 0; base.Add(base, bigOne) {
+		if isPalindromeInBase(n, base) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPalindromeInBase checks if n (assumed non-negative) reads the same
+// forwards and backwards when its digits are expressed in the given base.
+func isPalindromeInBase(n, base *big.Int) bool {
+	digits := digitsInBase(n, base)
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		if digits[i].Cmp(digits[j]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// digitsInBase returns the digits of n (assumed non-negative) in the given
+// base, least significant digit first.
+func digitsInBase(n, base *big.Int) []*big.Int {
+	if n.Sign() == 0 {
+		return []*big.Int{new(big.Int)}
+	}
+
+	var digits []*big.Int
+	quotient, digit := new(big.Int), new(big.Int)
+	remaining := new(big.Int).Set(n)
+
+	for remaining.Sign() > 0 {
+		quotient.QuoRem(remaining, base, digit)
+		digits = append(digits, new(big.Int).Set(digit))
+		remaining, quotient = quotient, remaining
+	}
+	return digits
+}
+
+// digitSum calculates the sum of n's (assumed non-negative) digits in the
+// given base.
+//
+// Examples:
+// - digitSum(123, 10) = 1 + 2 + 3 = 6
+// - digitSum(999, 10) = 9 + 9 + 9 = 27
+//
+// Parameters:
+//   - n: The non-negative number whose digits are to be summed
+//   - base: The base (2..36) to evaluate digits in
+//
+// Returns:
+//   - int64: The sum of n's digits in the given base
+func digitSum(n *big.Int, base int) int64 {
+	digits := digitsInBase(n, big.NewInt(int64(base)))
+	var sum int64
+	for _, digit := range digits {
+		sum += digit.Int64()
+	}
+	return sum
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+func mustInt(s string, base int) *big.Int {
+	n, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		panic("bad test input: " + s)
+	}
+	return n
+}
+
+func hasProperty(properties []string, want string) bool {
+	for _, p := range properties {
+		if p == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestClassifyPerfectAbundantDeficient(t *testing.T) {
+	cases := []struct {
+		name        string
+		n           int64
+		isPerfect   bool
+		isAbundant  bool
+		isDeficient bool
+	}{
+		{"perfect", 28, true, false, false},
+		{"abundant", 12, false, true, false},
+		{"deficient", 15, false, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := classify(big.NewInt(tc.n), 10)
+			if c.IsPerfect != tc.isPerfect || c.IsAbundant != tc.isAbundant || c.IsDeficient != tc.isDeficient {
+				t.Fatalf("classify(%d) = perfect=%v abundant=%v deficient=%v, want perfect=%v abundant=%v deficient=%v",
+					tc.n, c.IsPerfect, c.IsAbundant, c.IsDeficient, tc.isPerfect, tc.isAbundant, tc.isDeficient)
+			}
+		})
+	}
+}
+
+func TestClassifyAmicablePair(t *testing.T) {
+	for _, n := range []int64{220, 284} {
+		c := classify(big.NewInt(n), 10)
+		if !hasProperty(c.Properties, "amicable") {
+			t.Errorf("classify(%d).Properties = %v, want \"amicable\"", n, c.Properties)
+		}
+	}
+}
+
+func TestClassifyHappyUnhappy(t *testing.T) {
+	happy := classify(big.NewInt(19), 10)
+	if !happy.IsHappy || !hasProperty(happy.Properties, "happy") {
+		t.Errorf("classify(19) = IsHappy=%v Properties=%v, want happy", happy.IsHappy, happy.Properties)
+	}
+
+	unhappy := classify(big.NewInt(4), 10)
+	if unhappy.IsHappy || !hasProperty(unhappy.Properties, "unhappy") {
+		t.Errorf("classify(4) = IsHappy=%v Properties=%v, want unhappy", unhappy.IsHappy, unhappy.Properties)
+	}
+}
+
+func TestClassifyPronic(t *testing.T) {
+	c := classify(big.NewInt(20), 10) // 4*5
+	if !hasProperty(c.Properties, "pronic") {
+		t.Errorf("classify(20).Properties = %v, want \"pronic\"", c.Properties)
+	}
+
+	c = classify(big.NewInt(21), 10)
+	if hasProperty(c.Properties, "pronic") {
+		t.Errorf("classify(21).Properties = %v, want no \"pronic\"", c.Properties)
+	}
+}
+
+func TestClassifyStrictlyNonPalindromicSmallN(t *testing.T) {
+	// The base range 2..n-2 is empty for n < 4, so these are vacuously strictly
+	// non-palindromic.
+	for _, n := range []int64{0, 1, 2, 3} {
+		c := classify(big.NewInt(n), 10)
+		if !hasProperty(c.Properties, "strictly_non_palindromic") {
+			t.Errorf("classify(%d).Properties = %v, want \"strictly_non_palindromic\"", n, c.Properties)
+		}
+	}
+}
+
+func TestClassifyNegativeInput(t *testing.T) {
+	c := classify(big.NewInt(-17), 10)
+
+	if c.Sign != -1 {
+		t.Errorf("classify(-17).Sign = %d, want -1", c.Sign)
+	}
+	if !c.IsPrime {
+		t.Errorf("classify(-17).IsPrime = false, want true (primality is evaluated on |n|)")
+	}
+}
+
+func TestClassifyNonBase10(t *testing.T) {
+	// ff (base 16) == 255 (base 10): digit sum 15+15=30, not an Armstrong number
+	// in base 16 (2 digits, 15^2+15^2 != 255).
+	n := mustInt("ff", 16)
+	c := classify(n, 16)
+
+	if c.Base != 16 {
+		t.Errorf("classify(ff, base=16).Base = %d, want 16", c.Base)
+	}
+	if c.DigitSum != 30 {
+		t.Errorf("classify(ff, base=16).DigitSum = %d, want 30", c.DigitSum)
+	}
+	if hasProperty(c.Properties, "armstrong") {
+		t.Errorf("classify(ff, base=16).Properties = %v, want no \"armstrong\"", c.Properties)
+	}
+}
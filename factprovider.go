@@ -0,0 +1,275 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// funFactCacheCapacity bounds the number of fun facts the in-process cache keeps
+// around before evicting the least recently used entry.
+const funFactCacheCapacity = 256
+
+// FunFactProvider supplies a fun fact about a number. Implementations may hit the
+// network, read from memory, or fall back to a canned catalog; classifyNumber
+// doesn't care which, which is what lets tests inject a fake provider without
+// touching the network.
+type FunFactProvider interface {
+	Fact(ctx context.Context, n *big.Int) (string, error)
+}
+
+// newFunFactProvider builds the provider chain classifyNumber uses: the HTTP
+// provider (wrapped in an LRU cache so repeat requests don't re-hit numbersapi.com),
+// falling back to the offline catalog if the network call fails. When offline is
+// true the HTTP provider is omitted entirely, so the chain never attempts to reach
+// the network — suitable for air-gapped deployments.
+func newFunFactProvider(offline bool) FunFactProvider {
+	if offline {
+		return offlineCatalogProvider{}
+	}
+	return chainFunFactProvider{
+		newCachingFunFactProvider(newHTTPFunFactProvider(), funFactCacheCapacity),
+		offlineCatalogProvider{},
+	}
+}
+
+// chainFunFactProvider tries each provider in order, returning the first
+// successful fact. It is itself a FunFactProvider, so chains can be nested.
+type chainFunFactProvider []FunFactProvider
+
+func (c chainFunFactProvider) Fact(ctx context.Context, n *big.Int) (string, error) {
+	var lastErr error
+	for _, provider := range c {
+		fact, err := provider.Fact(ctx, n)
+		if err == nil {
+			return fact, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no fun fact providers configured")
+	}
+	return "", lastErr
+}
+
+// httpFunFactProvider fetches a fun fact from numbersapi.com. It honors ctx
+// cancellation and, if the upstream responds 429 Too Many Requests with a
+// Retry-After header, waits that long and retries once before giving up.
+type httpFunFactProvider struct {
+	client *http.Client
+}
+
+func newHTTPFunFactProvider() *httpFunFactProvider {
+	return &httpFunFactProvider{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (p *httpFunFactProvider) Fact(ctx context.Context, n *big.Int) (string, error) {
+	return p.fact(ctx, n, true)
+}
+
+// fact performs one fetch attempt, optionally retrying once after the
+// Retry-After delay if the upstream is rate-limiting us.
+func (p *httpFunFactProvider) fact(ctx context.Context, n *big.Int, allowRetry bool) (string, error) {
+	url := fmt.Sprintf("http://numbersapi.com/%s/math", n.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests && allowRetry {
+		if delay := parseRetryAfter(resp.Header.Get("Retry-After")); delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			return p.fact(ctx, n, false)
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("numbersapi: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// parseRetryAfter parses the delay-seconds form of a Retry-After header,
+// returning 0 if it is absent or in the HTTP-date form we don't handle.
+func parseRetryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// cachingFunFactProvider wraps another provider with an in-process LRU cache
+// keyed by the number's decimal string, so repeated requests for the same
+// number don't re-hit the upstream.
+type cachingFunFactProvider struct {
+	next  FunFactProvider
+	cache *lruCache
+}
+
+func newCachingFunFactProvider(next FunFactProvider, capacity int) *cachingFunFactProvider {
+	return &cachingFunFactProvider{next: next, cache: newLRUCache(capacity)}
+}
+
+func (p *cachingFunFactProvider) Fact(ctx context.Context, n *big.Int) (string, error) {
+	key := n.String()
+	if fact, ok := p.cache.get(key); ok {
+		return fact, nil
+	}
+
+	fact, err := p.next.Fact(ctx, n)
+	if err != nil {
+		return "", err
+	}
+
+	p.cache.put(key, fact)
+	return fact, nil
+}
+
+// lruCache is a small fixed-capacity least-recently-used string cache.
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key, value string
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) put(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// offlineCatalogProvider answers from a small set of well-known integer
+// sequences instead of the network. It never fails: numbers that don't match
+// any known sequence get a generic fact, the same way getFunFact used to
+// behave when the upstream was unreachable.
+type offlineCatalogProvider struct{}
+
+func (offlineCatalogProvider) Fact(ctx context.Context, n *big.Int) (string, error) {
+	abs := new(big.Int).Abs(n)
+	s := n.String()
+
+	switch {
+	case isPrime(abs):
+		return fmt.Sprintf("%s is a prime number.", s), nil
+	case fitsWithin(abs, divisorSearchLimit) && abs.Sign() > 0 && aliquotSum(abs).Cmp(abs) == 0:
+		return fmt.Sprintf("%s is a perfect number.", s), nil
+	case isArmstrong(abs, 10):
+		return fmt.Sprintf("%s is an Armstrong number.", s), nil
+	case isFibonacci(abs):
+		return fmt.Sprintf("%s is a Fibonacci number.", s), nil
+	case isFactorial(abs):
+		return fmt.Sprintf("%s is a factorial number.", s), nil
+	case isTriangular(abs):
+		return fmt.Sprintf("%s is a triangular (figurate) number.", s), nil
+	default:
+		return fmt.Sprintf("%s is an interesting number.", s), nil
+	}
+}
+
+// isFibonacci checks if n (assumed non-negative) belongs to the Fibonacci
+// sequence. n is a Fibonacci number iff 5n^2+4 or 5n^2-4 is a perfect square.
+func isFibonacci(n *big.Int) bool {
+	isPerfectSquare := func(x *big.Int) bool {
+		if x.Sign() < 0 {
+			return false
+		}
+		root := new(big.Int).Sqrt(x)
+		return new(big.Int).Mul(root, root).Cmp(x) == 0
+	}
+
+	fiveNSquared := new(big.Int).Mul(n, n)
+	fiveNSquared.Mul(fiveNSquared, big.NewInt(5))
+
+	plusFour := new(big.Int).Add(fiveNSquared, bigFour)
+	minusFour := new(big.Int).Sub(fiveNSquared, bigFour)
+
+	return isPerfectSquare(plusFour) || isPerfectSquare(minusFour)
+}
+
+// isFactorial checks if n (assumed non-negative) equals k! for some k >= 0.
+func isFactorial(n *big.Int) bool {
+	factorial := big.NewInt(1) // 0!
+	if factorial.Cmp(n) == 0 {
+		return true
+	}
+	for k := int64(1); ; k++ {
+		factorial.Mul(factorial, big.NewInt(k))
+		switch factorial.Cmp(n) {
+		case 0:
+			return true
+		case 1:
+			return false
+		}
+	}
+}
+
+// isTriangular checks if n (assumed non-negative) is a triangular number,
+// i.e. n = k*(k+1)/2 for some non-negative integer k. Triangular numbers are
+// the simplest family of figurate numbers.
+func isTriangular(n *big.Int) bool {
+	discriminant := new(big.Int).Mul(n, big.NewInt(8))
+	discriminant.Add(discriminant, bigOne)
+
+	root := new(big.Int).Sqrt(discriminant)
+	return new(big.Int).Mul(root, root).Cmp(discriminant) == 0
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLRUCache(2)
+	cache.put("a", "fact-a")
+	cache.put("b", "fact-b")
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	if _, ok := cache.get("a"); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+
+	cache.put("c", "fact-c") // should evict "b", not "a"
+
+	if _, ok := cache.get("b"); ok {
+		t.Fatalf("expected b to have been evicted")
+	}
+	if fact, ok := cache.get("a"); !ok || fact != "fact-a" {
+		t.Fatalf("expected a to still be cached with its value, got %q, %v", fact, ok)
+	}
+	if fact, ok := cache.get("c"); !ok || fact != "fact-c" {
+		t.Fatalf("expected c to be cached with its value, got %q, %v", fact, ok)
+	}
+}
+
+// fakeFunFactProvider is a FunFactProvider with a canned result, used to drive
+// chainFunFactProvider without touching the network.
+type fakeFunFactProvider struct {
+	fact string
+	err  error
+}
+
+func (f fakeFunFactProvider) Fact(ctx context.Context, n *big.Int) (string, error) {
+	return f.fact, f.err
+}
+
+func TestChainFunFactProviderFallsBackOnError(t *testing.T) {
+	chain := chainFunFactProvider{
+		fakeFunFactProvider{err: errors.New("boom")},
+		fakeFunFactProvider{fact: "42 is interesting"},
+	}
+
+	fact, err := chain.Fact(context.Background(), big.NewInt(42))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fact != "42 is interesting" {
+		t.Fatalf("expected fallback fact, got %q", fact)
+	}
+}
+
+func TestChainFunFactProviderReturnsErrorWhenAllFail(t *testing.T) {
+	chain := chainFunFactProvider{
+		fakeFunFactProvider{err: errors.New("first")},
+		fakeFunFactProvider{err: errors.New("second")},
+	}
+
+	if _, err := chain.Fact(context.Background(), big.NewInt(7)); err == nil {
+		t.Fatalf("expected an error when every provider fails")
+	}
+}
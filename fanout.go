@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultFanOutTimeout is the wall-clock budget for classify-numbers requests when
+// the caller does not supply a timeout_ms query parameter.
+const defaultFanOutTimeout = 500 * time.Millisecond
+
+// numbersDocument is the expected shape of the JSON document served at each source
+// URL passed to classify-numbers.
+type numbersDocument struct {
+	Numbers []int `json:"numbers"`
+}
+
+// BatchResponse is the JSON response structure for the classify-numbers endpoint.
+// It reports the merged, sorted, de-duplicated set of numbers gathered from all
+// reachable sources within the deadline, along with each one's classification
+// and fun fact.
+type BatchResponse struct {
+	Numbers []int            `json:"numbers"`
+	Results []NumberResponse `json:"results"`
+}
+
+// classifyNumbers is the HTTP handler for GET /api/classify-numbers. It fetches
+// one or more JSON documents of the form {"numbers":[...]} concurrently, merges
+// the integers into a sorted, de-duplicated set, and classifies each one.
+//
+// The whole operation, fetching, classifying, and fact lookups alike, is bounded
+// by a hard wall-clock deadline (default 500ms, overridable via the timeout_ms
+// query parameter). Any source URL, classification, or fact lookup that errors
+// or is still in flight when the deadline fires is silently dropped; an empty
+// "numbers" list is a valid outcome if every source fails or times out.
+//
+// Parameters:
+//   - c: Gin context containing the HTTP request and response utilities
+func classifyNumbers(c *gin.Context) {
+	urls := c.QueryArray("u")
+
+	timeout := defaultFanOutTimeout
+	if raw := c.Query("timeout_ms"); raw != "" {
+		if ms, err := strconv.Atoi(raw); err == nil && ms > 0 {
+			timeout = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+
+	merged := fetchAndMergeNumbers(ctx, urls)
+	numbers, results := classifyWithFacts(ctx, merged)
+
+	c.JSON(http.StatusOK, BatchResponse{
+		Numbers: numbers,
+		Results: results,
+	})
+}
+
+// classifyWithFacts classifies each of numbers and attaches a fun fact to each,
+// doing both concurrently since they're independent per number. Numbers come
+// from attacker-fetchable source URLs, so this is bounded by ctx just as
+// strictly as the source fetches: a goroutine bails before doing any work once
+// ctx is done, and the caller doesn't wait past ctx for stragglers, so a
+// classification already in flight when the deadline fires is the only thing
+// that can run over. It returns the subset of numbers (in their original
+// order) that finished in time, paired with their results.
+func classifyWithFacts(ctx context.Context, numbers []int) ([]int, []NumberResponse) {
+	results := make([]NumberResponse, len(numbers))
+	finished := make([]bool, len(numbers))
+
+	var wg sync.WaitGroup
+	for i, n := range numbers {
+		wg.Add(1)
+		go func(i, n int) {
+			defer wg.Done()
+			if ctx.Err() != nil {
+				return
+			}
+
+			number := big.NewInt(int64(n))
+			classification := classify(number, defaultBase)
+			if ctx.Err() != nil {
+				return
+			}
+
+			fact, err := funFactProvider.Fact(ctx, number)
+			if err != nil {
+				fact = number.String() + " is an interesting number."
+			}
+
+			results[i] = NumberResponse{
+				Classification: classification,
+				FunFact:        fact,
+			}
+			finished[i] = true
+		}(i, n)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	completedNumbers := make([]int, 0, len(numbers))
+	completedResults := make([]NumberResponse, 0, len(numbers))
+	for i, n := range numbers {
+		if finished[i] {
+			completedNumbers = append(completedNumbers, n)
+			completedResults = append(completedResults, results[i])
+		}
+	}
+	return completedNumbers, completedResults
+}
+
+// fetchAndMergeNumbers fetches urls concurrently and merges their numbers into a
+// sorted, de-duplicated slice. It returns as soon as ctx is done, even if some
+// fetches are still in flight; those are simply excluded from the result.
+func fetchAndMergeNumbers(ctx context.Context, urls []string) []int {
+	var mu sync.Mutex
+	seen := make(map[int]struct{})
+	merged := []int{} // never nil: an empty "numbers" list must still serialize as [], not null
+
+	var wg sync.WaitGroup
+	for _, u := range urls {
+		wg.Add(1)
+		go func(u string) {
+			defer wg.Done()
+			numbers, err := fetchNumbers(ctx, u)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, n := range numbers {
+				if _, ok := seen[n]; !ok {
+					seen[n] = struct{}{}
+					merged = append(merged, n)
+				}
+			}
+		}(u)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Ints(merged)
+	return merged
+}
+
+// fetchNumbers performs a context-bounded HTTP GET against u and decodes a
+// {"numbers":[...]} document from the response body.
+func fetchNumbers(ctx context.Context, u string) ([]int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var doc numbersDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return doc.Numbers, nil
+}
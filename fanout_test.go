@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchAndMergeNumbersReturnsPartialResultsOnDeadline(t *testing.T) {
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(numbersDocument{Numbers: []int{1, 2}})
+	}))
+	defer fast.Close()
+
+	blocked := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked // never responds before the test's deadline fires
+	}))
+	defer func() {
+		close(blocked)
+		slow.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	merged := fetchAndMergeNumbers(ctx, []string{fast.URL, slow.URL})
+
+	if len(merged) != 2 || merged[0] != 1 || merged[1] != 2 {
+		t.Fatalf("expected the fast source's numbers [1 2], got %v", merged)
+	}
+}
+
+func TestFetchAndMergeNumbersReturnsEmptySliceNotNilWhenAllFail(t *testing.T) {
+	merged := fetchAndMergeNumbers(context.Background(), []string{"http://127.0.0.1:0"})
+
+	if merged == nil {
+		t.Fatalf("expected an empty slice, got nil")
+	}
+	if len(merged) != 0 {
+		t.Fatalf("expected no numbers, got %v", merged)
+	}
+}